@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	cmdkit "github.com/ipfs/go-ipfs-cmdkit"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing/verify"
+)
+
+// slashingVerifierEnv is implemented by the node's command Env; it's the one thing slashingVerifyCmd
+// needs from the running node.
+type slashingVerifierEnv interface {
+	SlashingVerifier() *verify.Service
+}
+
+// usageErrorf reports a malformed request, as distinct from a rejected-but-well-formed one (the latter
+// is a normal Result with Rejected set, not a command error).
+func usageErrorf(format string, args ...interface{}) error {
+	return &cmdkit.Error{Code: cmdkit.ErrNormal, Message: fmt.Sprintf(format, args...)}
+}
+
+// slashingCmd groups the commands for inspecting and verifying consensus faults.
+var slashingCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Inspect and verify consensus faults",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"verify": slashingVerifyCmd,
+	},
+}
+
+// slashingVerifyCmd checks two block headers for a consensus fault without requiring a reporting
+// actor, the message pool, or a mined message at all; it's the same check VerifyConsensusFault runs on
+// chain, exposed standalone so third-party watchtowers can use it without embedding a full node.
+var slashingVerifyCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Check whether two block headers constitute a consensus fault",
+		ShortDescription: `
+Submits two block headers (and, for a parent-grinding fault, a witness header) to the same
+consensus fault check the chain runs, and prints the result: either a confirmed fault's
+fault_type/target/epoch, or the reason it was rejected.
+
+Headers may be given as hex (optionally 0x-prefixed) or base64. By default the headers must be
+provable against --head since --earliest, matching on-chain semantics ("strict" mode). Pass
+--dry-run to skip that chain-in-history check for offline analysis of headers pulled from an
+archive this process has no chain access to.
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("h1", true, false, "first block header"),
+		cmdkit.StringArg("h2", true, false, "second block header"),
+	},
+	Options: []cmdkit.Option{
+		cmdkit.StringOption("extra", "witness header proving a parent-grinding fault"),
+		cmdkit.StringOption("head", "comma-separated CIDs of the tipset to check chain history against (required unless --dry-run)"),
+		cmdkit.StringOption("earliest", "earliest epoch a header may still be provable at (required unless --dry-run)"),
+		cmdkit.BoolOption("dry-run", "skip the chain-in-history check, for offline analysis of archived headers"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		h1Str := req.Arguments[0]
+		h2Str := req.Arguments[1]
+		extraStr, _ := req.Options["extra"].(string)
+		dryRun, _ := req.Options["dry-run"].(bool)
+		headStr, _ := req.Options["head"].(string)
+		earliestStr, _ := req.Options["earliest"].(string)
+
+		vreq, err := parseSlashingVerifyRequest(h1Str, h2Str, extraStr, headStr, earliestStr, dryRun)
+		if err != nil {
+			return err
+		}
+
+		result, err := env.(slashingVerifierEnv).SlashingVerifier().Verify(req.Context, vreq)
+		if err != nil {
+			return err
+		}
+		return re.Emit(&result)
+	},
+	Type: verify.Result{},
+}
+
+// parseSlashingVerifyRequest builds a verify.Request out of slashingVerifyCmd's raw argument/option
+// strings. It is split out from Run so the parsing logic can be tested without the cmds framework.
+func parseSlashingVerifyRequest(h1Str, h2Str, extraStr, headStr, earliestStr string, dryRun bool) (verify.Request, error) {
+	h1, err := verify.DecodeHeader(h1Str)
+	if err != nil {
+		return verify.Request{}, usageErrorf("h1: %s", err)
+	}
+	h2, err := verify.DecodeHeader(h2Str)
+	if err != nil {
+		return verify.Request{}, usageErrorf("h2: %s", err)
+	}
+
+	vreq := verify.Request{H1: h1, H2: h2}
+	if extraStr != "" {
+		vreq.Extra, err = verify.DecodeHeader(extraStr)
+		if err != nil {
+			return verify.Request{}, usageErrorf("extra: %s", err)
+		}
+	}
+
+	if dryRun {
+		vreq.Mode = verify.ModeDryRun
+		return vreq, nil
+	}
+
+	vreq.Mode = verify.ModeStrict
+	if headStr == "" {
+		return verify.Request{}, usageErrorf("--head is required unless --dry-run is set")
+	}
+	var cids []cid.Cid
+	for _, s := range strings.Split(headStr, ",") {
+		c, err := cid.Decode(s)
+		if err != nil {
+			return verify.Request{}, usageErrorf("--head: %s", err)
+		}
+		cids = append(cids, c)
+	}
+	vreq.Head = block.NewTipSetKey(cids...)
+
+	earliest, err := strconv.ParseInt(earliestStr, 10, 64)
+	if err != nil {
+		return verify.Request{}, usageErrorf("--earliest: %s", err)
+	}
+	vreq.Earliest = abi.ChainEpoch(earliest)
+
+	return vreq, nil
+}
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing/verify"
+)
+
+func TestParseSlashingVerifyRequestDryRun(t *testing.T) {
+	h1 := hex.EncodeToString([]byte("header-one"))
+	h2 := hex.EncodeToString([]byte("header-two"))
+
+	req, err := parseSlashingVerifyRequest(h1, h2, "", "", "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(req.H1) != "header-one" || string(req.H2) != "header-two" {
+		t.Fatalf("headers not decoded correctly: %+v", req)
+	}
+	if req.Mode != verify.ModeDryRun {
+		t.Fatalf("expected dry-run mode, got %q", req.Mode)
+	}
+}
+
+func TestParseSlashingVerifyRequestStrictRequiresHead(t *testing.T) {
+	h1 := hex.EncodeToString([]byte("header-one"))
+	h2 := hex.EncodeToString([]byte("header-two"))
+
+	if _, err := parseSlashingVerifyRequest(h1, h2, "", "", "0", false); err == nil {
+		t.Fatal("expected an error when --head is missing in strict mode")
+	}
+}
+
+func TestParseSlashingVerifyRequestStrictParsesHeadAndEarliest(t *testing.T) {
+	h1 := hex.EncodeToString([]byte("header-one"))
+	h2 := hex.EncodeToString([]byte("header-two"))
+	headCid := "bafy2bzacectqdqlvoz3rdtmzo4qhgvhsjt2dhm5qsxdfpdivwqxqzzeedpf6q"
+
+	req, err := parseSlashingVerifyRequest(h1, h2, "", headCid, "42", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if req.Earliest != 42 {
+		t.Fatalf("expected earliest epoch 42, got %d", req.Earliest)
+	}
+	if len(req.Head.Cids()) != 1 {
+		t.Fatalf("expected a single-CID head tipset key, got %+v", req.Head)
+	}
+}
+
+func TestParseSlashingVerifyRequestBadHeaderIsUsageError(t *testing.T) {
+	if _, err := parseSlashingVerifyRequest("not hex or base64 !!", hex.EncodeToString([]byte("h2")), "", "", "", true); err == nil {
+		t.Fatal("expected an error for an undecodable h1")
+	}
+}
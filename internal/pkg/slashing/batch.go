@@ -0,0 +1,229 @@
+package slashing
+
+import (
+	"bytes"
+	"context"
+	goruntime "runtime"
+	"sync"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+)
+
+// FaultReport is one candidate consensus fault to check, in the same encoding as VerifyConsensusFault's
+// h1/h2/extra arguments.
+type FaultReport struct {
+	H1    []byte
+	H2    []byte
+	Extra []byte
+}
+
+// FaultResult is the outcome of checking a single FaultReport: either a confirmed fault, or the reason
+// it was rejected.
+type FaultResult struct {
+	Fault *runtime.ConsensusFault
+	Err   error
+}
+
+// VerifyConsensusFaults verifies many fault reports against the same head/view/earliest concurrently,
+// using a worker pool bounded by goruntime.NumCPU(). Signature checks and the chain-ancestor walk are
+// each memoized across reports, so N reports that share a block or a (head, earliest) pair don't
+// re-verify or re-traverse the chain N times; this is deduplication, not batched/aggregated signature
+// verification, so N reports about N distinct blocks still pay N individual VerifySignature calls.
+// This is the path a slasher uses to submit hundreds of candidate reports scanning historical epochs
+// without paying O(N·depth) chain iteration or re-checking a block's signature it's already seen.
+func (s *ConsensusFaultChecker) VerifyConsensusFaults(ctx context.Context, reports []FaultReport, head block.TipSetKey, view FaultStateView, earliest abi.ChainEpoch) []FaultResult {
+	results := make([]FaultResult, len(reports))
+	if len(reports) == 0 {
+		return results
+	}
+
+	ancestors := newAncestorCache()
+	sigs := &sigCache{}
+
+	workers := goruntime.NumCPU()
+	if workers > len(reports) {
+		workers = len(reports)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.verifyReportCached(ctx, reports[i], head, view, earliest, ancestors, sigs)
+			}
+		}()
+	}
+	for i := range reports {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// verifyReportCached is VerifyConsensusFault's logic, but checking signatures and chain membership
+// through caches shared across an entire VerifyConsensusFaults call instead of the checker's verifier
+// and chain directly.
+func (s *ConsensusFaultChecker) verifyReportCached(ctx context.Context, r FaultReport, head block.TipSetKey, view FaultStateView, earliest abi.ChainEpoch, ancestors *ancestorCache, sigs *sigCache) FaultResult {
+	if bytes.Equal(r.H1, r.H2) {
+		return FaultResult{Err: ErrIdenticalBlocks}
+	}
+
+	var b1, b2, b3 block.Block
+	if err := encoding.Decode(r.H1, &b1); err != nil {
+		return FaultResult{Err: errors.Wrapf(ErrDecodeHeader, "h1: %s", err)}
+	}
+	if err := encoding.Decode(r.H2, &b2); err != nil {
+		return FaultResult{Err: errors.Wrapf(ErrDecodeHeader, "h2: %s", err)}
+	}
+	if len(r.Extra) > 0 {
+		if err := encoding.Decode(r.Extra, &b3); err != nil {
+			return FaultResult{Err: errors.Wrapf(ErrDecodeHeader, "extra: %s", err)}
+		}
+	}
+
+	if b1.Miner != b2.Miner {
+		return FaultResult{Err: ErrDifferentMiners}
+	}
+	if b1.Height > b2.Height {
+		return FaultResult{Err: ErrHeightOrder}
+	}
+
+	fault := detectFault(b1, b2, b3)
+	if fault == nil {
+		return FaultResult{Err: ErrNoFault}
+	}
+
+	if err := sigs.verify(ctx, s.verifier, view, b1); err != nil {
+		return FaultResult{Err: err}
+	}
+	if err := sigs.verify(ctx, s.verifier, view, b2); err != nil {
+		return FaultResult{Err: err}
+	}
+
+	inChain, err := ancestors.contains(ctx, s.chain, head, earliest, b1)
+	if err != nil {
+		return FaultResult{Err: errors.Wrapf(ErrChainLookup, "inspect chain for %s: %s", b1.Cid(), err)}
+	}
+	if !inChain {
+		inChain, err = ancestors.contains(ctx, s.chain, head, earliest, b2)
+		if err != nil {
+			return FaultResult{Err: errors.Wrapf(ErrChainLookup, "inspect chain for %s: %s", b2.Cid(), err)}
+		}
+	}
+	if !inChain {
+		return FaultResult{Err: errors.Wrapf(ErrBlockNotInChain, "since epoch %d", earliest)}
+	}
+
+	return FaultResult{Fault: fault}
+}
+
+// sigCache memoizes VerifySignature results by block CID, so that reports which reference the same
+// block (a common case when several candidate faults are raised against one miner) verify it once. It
+// deduplicates repeated blocks; it does not aggregate or batch the underlying signature checks for
+// distinct blocks, each of which still pays its own VerifySignature call.
+type sigCache struct {
+	results sync.Map // cid.Cid -> error
+}
+
+func (c *sigCache) verify(ctx context.Context, verifier Verifier, view FaultStateView, blk block.Block) error {
+	if v, ok := c.results.Load(blk.Cid()); ok {
+		if v == nil {
+			return nil
+		}
+		return v.(error)
+	}
+	err := verifier.VerifySignature(ctx, view, blk)
+	c.results.Store(blk.Cid(), err)
+	return err
+}
+
+// ancestorCache memoizes the set of ancestor block CIDs walked from a given (head, earliest) pair, so
+// that reports sharing that pair look membership up in a map instead of each re-walking the chain.
+type ancestorCache struct {
+	mu      sync.Mutex
+	entries map[ancestorCacheKey]*ancestorEntry
+}
+
+type ancestorCacheKey struct {
+	head     block.TipSetKey
+	earliest abi.ChainEpoch
+}
+
+type ancestorEntry struct {
+	once sync.Once
+	cids map[cid.Cid]struct{}
+	err  error
+}
+
+func newAncestorCache() *ancestorCache {
+	return &ancestorCache{entries: make(map[ancestorCacheKey]*ancestorEntry)}
+}
+
+func (c *ancestorCache) entryFor(head block.TipSetKey, earliest abi.ChainEpoch) *ancestorEntry {
+	key := ancestorCacheKey{head: head, earliest: earliest}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &ancestorEntry{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// contains reports whether blk appears in the chain defined by head, since earliest, computing (and
+// memoizing) the full set of ancestor block CIDs the first time it's needed for this (head, earliest)
+// pair.
+func (c *ancestorCache) contains(ctx context.Context, chn chainReader, head block.TipSetKey, earliest abi.ChainEpoch, blk block.Block) (bool, error) {
+	if blk.Height < earliest { // Short-circuit, same as chainContainsBlock.
+		return false, nil
+	}
+	e := c.entryFor(head, earliest)
+	e.once.Do(func() {
+		e.cids, e.err = collectAncestorCids(ctx, chn, head, earliest)
+	})
+	if e.err != nil {
+		return false, e.err
+	}
+	_, found := e.cids[blk.Cid()]
+	return found, nil
+}
+
+func collectAncestorCids(ctx context.Context, chn chainReader, head block.TipSetKey, earliest abi.ChainEpoch) (map[cid.Cid]struct{}, error) {
+	seen := make(map[cid.Cid]struct{})
+	ts, err := chn.GetTipSet(head)
+	if err != nil {
+		return nil, err
+	}
+
+	itr := chain.IterAncestors(ctx, chn, ts)
+	for ; !itr.Complete(); err = itr.Next() {
+		if err != nil {
+			return nil, err
+		}
+		ts := itr.Value()
+		height, err := ts.Height()
+		if err != nil {
+			return nil, err
+		}
+		if height < earliest {
+			break
+		}
+		for _, c := range ts.Key().Cids() {
+			seen[c] = struct{}{}
+		}
+	}
+	return seen, nil
+}
@@ -0,0 +1,83 @@
+package slashing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+)
+
+func TestSigCacheMemoizesPerBlock(t *testing.T) {
+	miner := mustAddress(t, 100)
+	blk := block.Block{Miner: miner, Height: 10, Parents: tipSetKeyFromSeeds(t, 1)}
+	verifier := &fakeVerifier{badSig: map[string]bool{blk.Cid().String(): true}}
+
+	sigs := &sigCache{}
+	for i := 0; i < 5; i++ {
+		err := sigs.verify(context.Background(), verifier, nil, blk)
+		if !errors.Is(err, ErrBadSignature) {
+			t.Fatalf("call %d: expected ErrBadSignature, got %v", i, err)
+		}
+	}
+
+	if verifier.calls != 1 {
+		t.Fatalf("expected VerifySignature to run once for a memoized block, got %d calls", verifier.calls)
+	}
+}
+
+// TestVerifyConsensusFaultsConcurrentResultsMatchIndex exercises the worker pool across many
+// independent reports, each rejected for a different reason purely from its own content (so no
+// report depends on another), and checks every result lands at its originating report's index.
+func TestVerifyConsensusFaultsConcurrentResultsMatchIndex(t *testing.T) {
+	const n = 32
+	reports := make([]FaultReport, n)
+	want := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		switch i % 3 {
+		case 0:
+			// Same header twice: rejected before ever decoding a second time.
+			b := block.Block{Miner: mustAddress(t, uint64(1000+i)), Height: abi.ChainEpoch(10 + i), Parents: tipSetKeyFromSeeds(t, byte(i))}
+			h, err := encoding.Encode(b)
+			if err != nil {
+				t.Fatalf("failed to encode test block %d: %s", i, err)
+			}
+			reports[i] = FaultReport{H1: h, H2: h}
+			want[i] = ErrIdenticalBlocks
+		case 1:
+			// Garbage bytes: rejected at decode.
+			reports[i] = FaultReport{H1: []byte("not a valid header"), H2: []byte("also not valid")}
+			want[i] = ErrDecodeHeader
+		default:
+			// Two distinct miners, same epoch: rejected as mined by different miners.
+			b1 := block.Block{Miner: mustAddress(t, uint64(2000+i)), Height: abi.ChainEpoch(10 + i), Parents: tipSetKeyFromSeeds(t, byte(i))}
+			b2 := block.Block{Miner: mustAddress(t, uint64(3000+i)), Height: abi.ChainEpoch(10 + i), Parents: tipSetKeyFromSeeds(t, byte(i+1))}
+			h1, err := encoding.Encode(b1)
+			if err != nil {
+				t.Fatalf("failed to encode test block %d: %s", i, err)
+			}
+			h2, err := encoding.Encode(b2)
+			if err != nil {
+				t.Fatalf("failed to encode test block %d: %s", i, err)
+			}
+			reports[i] = FaultReport{H1: h1, H2: h2}
+			want[i] = ErrDifferentMiners
+		}
+	}
+
+	checker := NewFaultCheckerWithVerifier(fakeChainReader{}, &fakeVerifier{})
+	results := checker.VerifyConsensusFaults(context.Background(), reports, block.TipSetKey{}, nil, 0)
+
+	if len(results) != n {
+		t.Fatalf("expected %d results, got %d", n, len(results))
+	}
+	for i, r := range results {
+		if !errors.Is(r.Err, want[i]) {
+			t.Fatalf("result %d: expected %v, got %v", i, want[i], r.Err)
+		}
+	}
+}
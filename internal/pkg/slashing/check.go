@@ -3,7 +3,6 @@ package slashing
 import (
 	"bytes"
 	"context"
-	"fmt"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/specs-actors/actors/abi"
@@ -28,33 +27,64 @@ type chainReader interface {
 
 // Checks the validity of reported consensus faults.
 type ConsensusFaultChecker struct {
-	chain chainReader
+	chain    chainReader
+	verifier Verifier
 }
 
 func NewFaultChecker(chain chainReader) *ConsensusFaultChecker {
-	return &ConsensusFaultChecker{chain: chain}
+	return NewFaultCheckerWithVerifier(chain, defaultVerifier{})
+}
+
+// NewFaultCheckerWithVerifier is NewFaultChecker with an explicit Verifier, letting callers swap in a
+// batched or FFI-accelerated signature verifier without changing the fault-checking logic below.
+func NewFaultCheckerWithVerifier(chain chainReader, verifier Verifier) *ConsensusFaultChecker {
+	return &ConsensusFaultChecker{chain: chain, verifier: verifier}
 }
 
 // Checks the validity of a consensus fault reported by serialized block headers h1, h2, and optional
 // common-ancestor witness h3.
 func (s *ConsensusFaultChecker) VerifyConsensusFault(ctx context.Context, h1, h2, extra []byte, head block.TipSetKey, view FaultStateView, earliest abi.ChainEpoch) (*runtime.ConsensusFault, error) {
+	fault, b1, b2, err := s.verifyConsensusFaultOffChain(ctx, h1, h2, extra, view)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyOneBlockInChain(ctx, s.chain, head, b1, b2, earliest); err != nil {
+		return nil, err
+	}
+
+	return fault, nil
+}
+
+// VerifyConsensusFaultOffline is VerifyConsensusFault without the chain-in-history check, for callers
+// (e.g. a standalone CLI or RPC endpoint) inspecting two headers in isolation, without access to the
+// chain they were purportedly mined against.
+func (s *ConsensusFaultChecker) VerifyConsensusFaultOffline(ctx context.Context, h1, h2, extra []byte, view FaultStateView) (*runtime.ConsensusFault, error) {
+	fault, _, _, err := s.verifyConsensusFaultOffChain(ctx, h1, h2, extra, view)
+	return fault, err
+}
+
+// verifyConsensusFaultOffChain is the part of VerifyConsensusFault that doesn't need chain access:
+// decode, detect, and check signatures. It also returns the decoded b1/b2 so VerifyConsensusFault can
+// run the chain-in-history check without re-decoding.
+func (s *ConsensusFaultChecker) verifyConsensusFaultOffChain(ctx context.Context, h1, h2, extra []byte, view FaultStateView) (*runtime.ConsensusFault, block.Block, block.Block, error) {
 	if bytes.Equal(h1, h2) {
-		return nil, fmt.Errorf("no consensus fault: blocks identical")
+		return nil, block.Block{}, block.Block{}, ErrIdenticalBlocks
 	}
 
 	var b1, b2, b3 block.Block
 	innerErr := encoding.Decode(h1, &b1)
 	if innerErr != nil {
-		return nil, errors.Wrapf(innerErr, "failed to decode h1")
+		return nil, block.Block{}, block.Block{}, errors.Wrapf(ErrDecodeHeader, "h1: %s", innerErr)
 	}
 	innerErr = encoding.Decode(h2, &b2)
 	if innerErr != nil {
-		return nil, errors.Wrapf(innerErr, "failed to decode h2")
+		return nil, block.Block{}, block.Block{}, errors.Wrapf(ErrDecodeHeader, "h2: %s", innerErr)
 	}
 	if len(extra) > 0 {
 		innerErr = encoding.Decode(extra, &b3)
 		if innerErr != nil {
-			return nil, errors.Wrapf(innerErr, "failed to decode extra")
+			return nil, block.Block{}, block.Block{}, errors.Wrapf(ErrDecodeHeader, "extra: %s", innerErr)
 		}
 	}
 	// Block syntax is not validated. This implements the strictest check possible, and is also the simplest check
@@ -63,119 +93,96 @@ func (s *ConsensusFaultChecker) VerifyConsensusFault(ctx context.Context, h1, h2
 	// are still fault-able.
 
 	if b1.Miner != b2.Miner {
-		return nil, fmt.Errorf("no consensus fault: miners differ")
+		return nil, block.Block{}, block.Block{}, ErrDifferentMiners
 	}
 	if b1.Height > b2.Height {
-		return nil, fmt.Errorf("no consensus fault: first block is higher than second")
+		return nil, block.Block{}, block.Block{}, ErrHeightOrder
 	}
 
-	// Check the basic fault conditions first, defer the (expensive) signature and chain history check until last.
-	var fault *runtime.ConsensusFault
-
-	// Double-fork mining fault: two blocks at the same epoch.
-	// It is not necessary to present a common ancestor of the blocks.
-	if b1.Height == b2.Height {
-		fault = &runtime.ConsensusFault{
-			Target: b1.Miner,
-			Epoch:  b2.Height,
-			Type:   runtime.ConsensusFaultDoubleForkMining,
-		}
-	}
-	// Time-offset mining fault: two blocks with the same parent but different epochs.
-	// The height check is redundant at time of writing, but included for robustness to future changes to this method.
-	// The blocks have a common ancestor by definition (the parent).
-	if b1.Parents.Equals(b2.Parents) && b1.Height != b2.Height {
-		fault = &runtime.ConsensusFault{
-			Target: b1.Miner,
-			Epoch:  b2.Height,
-			Type:   runtime.ConsensusFaultTimeOffsetMining,
-		}
-	}
-	// Parent-grinding fault: one block’s parent is a tipset that provably should have included some block but does not.
-	// The provable case is that two blocks are mined in consecutive epochs and the later one does not include the
-	// earlier one as a parent.
-	// B3 must prove that the higher block (B2) has grandparent equal to B1's parent.
-	if b1.Height+1 == b2.Height && !b2.Parents.Has(b1.Cid()) && b2.Parents.Has(b3.Cid()) && b3.Parents.Equals(b1.Parents) {
-		fault = &runtime.ConsensusFault{
-			Target: b1.Miner,
-			Epoch:  b2.Height,
-			Type:   runtime.ConsensusFaultParentGrinding,
-		}
-	}
+	fault := detectFault(b1, b2, b3)
 	if fault == nil {
-		return nil, fmt.Errorf("no consensus fault: blocks are ok")
+		return nil, block.Block{}, block.Block{}, ErrNoFault
 	}
 
-	// Expensive validation: signatures and chain history.
+	// Expensive validation: signatures. Chain history, if required, is left to the caller.
 
-	err := verifyBlockSignature(ctx, view, b1)
-	if err != nil {
-		return nil, err
+	if err := s.verifier.VerifySignature(ctx, view, b1); err != nil {
+		return nil, block.Block{}, block.Block{}, err
 	}
-	err = verifyBlockSignature(ctx, view, b2)
-	if err != nil {
-		return nil, err
-	}
-	err = verifyOneBlockInChain(ctx, s.chain, head, b1, b2, earliest)
-	if err != nil {
-		return nil, err
+	if err := s.verifier.VerifySignature(ctx, view, b2); err != nil {
+		return nil, block.Block{}, block.Block{}, err
 	}
 
-	return fault, nil
+	return fault, b1, b2, nil
 }
 
 // Checks whether a block header is correctly signed in the context of the parent state to which it refers.
+// This is the default Verifier.VerifySignature implementation.
 func verifyBlockSignature(ctx context.Context, view FaultStateView, blk block.Block) error {
 	_, worker, err := view.MinerControlAddresses(ctx, blk.Miner)
 	if err != nil {
-		panic(errors.Wrapf(err, "failed to inspect miner addresses"))
+		return errors.Wrapf(ErrChainLookup, "inspect miner addresses for %s: %s", blk.Miner, err)
 	}
 	err = state.NewSignatureValidator(view).ValidateSignature(ctx, blk.SignatureData(), worker, blk.BlockSig)
 	if err != nil {
-		return errors.Wrapf(err, "no consensus fault: block %s signature invalid", blk.Cid())
+		return errors.Wrapf(ErrBadSignature, "block %s: %s", blk.Cid(), err)
 	}
-	return err
+	return nil
 }
 
 // Checks whether at least one of b1, b2 appear in the chain defined by `head`.
 func verifyOneBlockInChain(ctx context.Context, chn chainReader, head block.TipSetKey, b1 block.Block, b2 block.Block, earliest abi.ChainEpoch) error {
-	if chainHasB1, err := chainContainsBlock(ctx, chn, head, b1, earliest); err != nil {
-		panic(errors.Wrapf(err, "failed to inspect chain")) // This idiosyncratic failure shouldn't go on chain
-	} else if chainHasB1 {
+	chainHasB1, err := chainContainsBlock(ctx, chn, head, b1, earliest)
+	if err != nil {
+		return errors.Wrapf(ErrChainLookup, "inspect chain for %s: %s", b1.Cid(), err)
+	}
+	if chainHasB1 {
 		return nil
 	}
-	if chainHasB2, err := chainContainsBlock(ctx, chn, head, b2, earliest); err != nil {
-		panic(errors.Wrapf(err, "failed to inspect chain"))
-	} else if chainHasB2 {
+	chainHasB2, err := chainContainsBlock(ctx, chn, head, b2, earliest)
+	if err != nil {
+		return errors.Wrapf(ErrChainLookup, "inspect chain for %s: %s", b2.Cid(), err)
+	}
+	if chainHasB2 {
 		return nil
 	}
-	return fmt.Errorf("no consensus fault: neither block in chain since %d", earliest)
+	return errors.Wrapf(ErrBlockNotInChain, "since epoch %d", earliest)
 }
 
 func chainContainsBlock(ctx context.Context, chn chainReader, head block.TipSetKey, blk block.Block, earliest abi.ChainEpoch) (bool, error) {
+	found, _, err := chainContainsBlockMetered(ctx, chn, head, blk, earliest)
+	return found, err
+}
+
+// chainContainsBlockMetered is chainContainsBlock instrumented with the count of ancestor tipsets
+// walked, so that callers charging gas for the traversal (e.g. VerifyConsensusFaultFVM) can account
+// for it precisely rather than assuming a worst case.
+func chainContainsBlockMetered(ctx context.Context, chn chainReader, head block.TipSetKey, blk block.Block, earliest abi.ChainEpoch) (bool, int64, error) {
 	if blk.Height < earliest { // Short-circuit
-		return false, nil
+		return false, 0, nil
 	}
 	ts, err := chn.GetTipSet(head)
 	if err != nil {
-		return false, err
+		return false, 0, err
 	}
 
+	var tipsetsWalked int64
 	itr := chain.IterAncestors(ctx, chn, ts)
 	for ts := itr.Value(); !itr.Complete(); err = itr.Next() {
 		if err != nil {
-			return false, err
+			return false, tipsetsWalked, err
 		}
+		tipsetsWalked++
 		height, err := ts.Height()
 		if err != nil {
-			return false, err
+			return false, tipsetsWalked, err
 		}
 		if height < earliest {
-			return false, nil
+			return false, tipsetsWalked, nil
 		}
 		if ts.Key().Has(blk.Cid()) {
-			return true, nil
+			return true, tipsetsWalked, nil
 		}
 	}
-	return false, nil
+	return false, tipsetsWalked, nil
 }
@@ -0,0 +1,42 @@
+package slashing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+)
+
+// TestVerifyConsensusFaultChainLookupFailure proves that a failure to inspect the chain surfaces as
+// ErrChainLookup, distinguishable via errors.Is from a genuine rejection like ErrNoFault or
+// ErrBlockNotInChain, rather than panicking or being indistinguishable from either.
+func TestVerifyConsensusFaultChainLookupFailure(t *testing.T) {
+	miner := mustAddress(t, 100)
+	parents := tipSetKeyFromSeeds(t, 1)
+	b1 := block.Block{Miner: miner, Height: 10, Parents: parents}
+	b2 := block.Block{Miner: miner, Height: 10, Parents: tipSetKeyFromSeeds(t, 2)}
+
+	h1, err := encoding.Encode(b1)
+	if err != nil {
+		t.Fatalf("failed to encode b1: %s", err)
+	}
+	h2, err := encoding.Encode(b2)
+	if err != nil {
+		t.Fatalf("failed to encode b2: %s", err)
+	}
+
+	checker := NewFaultCheckerWithVerifier(fakeChainReader{}, &fakeVerifier{})
+	fault, err := checker.VerifyConsensusFault(context.Background(), h1, h2, nil, block.TipSetKey{}, nil, 0)
+
+	if fault != nil {
+		t.Fatalf("expected no confirmed fault, got %+v", fault)
+	}
+	if !errors.Is(err, ErrChainLookup) {
+		t.Fatalf("expected ErrChainLookup, got %v", err)
+	}
+	if errors.Is(err, ErrNoFault) || errors.Is(err, ErrBlockNotInChain) {
+		t.Fatalf("chain-lookup failure must not look like a verdict: got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package slashing
+
+import "github.com/pkg/errors"
+
+// Sentinel errors returned by VerifyConsensusFault (and the per-report errors in VerifyConsensusFaults'
+// results), so callers can use errors.Is/errors.As to tell a validation verdict — "no fault" — apart
+// from ErrChainLookup, a transient infrastructure failure that should be retried rather than treated as
+// a rejection.
+var (
+	// ErrIdenticalBlocks is returned when h1 and h2 are byte-identical.
+	ErrIdenticalBlocks = errors.New("no consensus fault: blocks identical")
+	// ErrDifferentMiners is returned when h1 and h2 were mined by different miners.
+	ErrDifferentMiners = errors.New("no consensus fault: miners differ")
+	// ErrHeightOrder is returned when h1 is higher than h2.
+	ErrHeightOrder = errors.New("no consensus fault: first block is higher than second")
+	// ErrNoFault is returned when h1 and h2 don't match any of the three fault conditions.
+	ErrNoFault = errors.New("no consensus fault: blocks are ok")
+	// ErrBlockNotInChain is returned when neither h1 nor h2 can be found in the chain since earliest.
+	ErrBlockNotInChain = errors.New("no consensus fault: neither block in chain")
+	// ErrBadSignature is returned when a block's signature does not verify.
+	ErrBadSignature = errors.New("no consensus fault: block signature invalid")
+	// ErrDecodeHeader is returned when h1, h2 or extra fail to decode as a block header.
+	ErrDecodeHeader = errors.New("failed to decode block header")
+	// ErrChainLookup is returned when inspecting chain state (miner addresses, tipsets) fails for a
+	// reason unrelated to the report itself, e.g. a local datastore error. Unlike the errors above, this
+	// is not a verdict: callers should treat it as a transient infrastructure failure and may retry.
+	ErrChainLookup = errors.New("consensus fault check: chain lookup failed")
+)
@@ -0,0 +1,143 @@
+package slashing
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	logging "github.com/ipfs/go-log/v2"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+)
+
+var log = logging.Logger("slashing")
+
+// Gas charged for each expensive step of a consensus fault check, mirroring the FVM convention of
+// pricing CBOR decode, signature verification and chain-ancestor traversal independently so the
+// cost reflects work actually performed rather than a single flat fee.
+const (
+	GasCostDecodeBlockHeader  int64 = 1000
+	GasCostVerifyBlockSig     int64 = 50000
+	GasCostAncestorTipsetStep int64 = 10
+)
+
+// VerifyConsensusFaultFVM is the FVM-style counterpart to VerifyConsensusFault: it never returns a Go
+// error. Problems with the report are logged and treated as "no fault", and the gas consumed while
+// reaching that verdict is returned so the caller can charge the invoking actor deterministically,
+// matching the convention other Filecoin implementations moved to so that a malformed report burns
+// gas instead of aborting message execution.
+func (s *ConsensusFaultChecker) VerifyConsensusFaultFVM(ctx context.Context, h1, h2, extra []byte, head block.TipSetKey, view FaultStateView, earliest abi.ChainEpoch) (*runtime.ConsensusFault, int64) {
+	var gasUsed int64
+
+	if bytes.Equal(h1, h2) {
+		log.Infof("consensus fault check: blocks identical, no fault")
+		return nil, gasUsed
+	}
+
+	var b1, b2, b3 block.Block
+	if err := encoding.Decode(h1, &b1); err != nil {
+		gasUsed += GasCostDecodeBlockHeader
+		log.Warnf("consensus fault check: failed to decode h1: %s", err)
+		return nil, gasUsed
+	}
+	gasUsed += GasCostDecodeBlockHeader
+
+	if err := encoding.Decode(h2, &b2); err != nil {
+		gasUsed += GasCostDecodeBlockHeader
+		log.Warnf("consensus fault check: failed to decode h2: %s", err)
+		return nil, gasUsed
+	}
+	gasUsed += GasCostDecodeBlockHeader
+
+	if len(extra) > 0 {
+		if err := encoding.Decode(extra, &b3); err != nil {
+			gasUsed += GasCostDecodeBlockHeader
+			log.Warnf("consensus fault check: failed to decode extra: %s", err)
+			return nil, gasUsed
+		}
+		gasUsed += GasCostDecodeBlockHeader
+	}
+
+	fault := detectFault(b1, b2, b3)
+	if fault == nil {
+		log.Infof("consensus fault check: blocks are ok, no fault")
+		return nil, gasUsed
+	}
+
+	if err := s.verifier.VerifySignature(ctx, view, b1); err != nil {
+		gasUsed += GasCostVerifyBlockSig
+		log.Warnf("consensus fault check: block %s signature invalid: %s", b1.Cid(), err)
+		return nil, gasUsed
+	}
+	gasUsed += GasCostVerifyBlockSig
+
+	if err := s.verifier.VerifySignature(ctx, view, b2); err != nil {
+		gasUsed += GasCostVerifyBlockSig
+		log.Warnf("consensus fault check: block %s signature invalid: %s", b2.Cid(), err)
+		return nil, gasUsed
+	}
+	gasUsed += GasCostVerifyBlockSig
+
+	foundB1, walkedB1, err := chainContainsBlockMetered(ctx, s.chain, head, b1, earliest)
+	gasUsed += walkedB1 * GasCostAncestorTipsetStep
+	if err != nil {
+		log.Errorf("consensus fault check: failed to inspect chain for b1: %s", err)
+		return nil, gasUsed
+	}
+	if !foundB1 {
+		foundB2, walkedB2, err := chainContainsBlockMetered(ctx, s.chain, head, b2, earliest)
+		gasUsed += walkedB2 * GasCostAncestorTipsetStep
+		if err != nil {
+			log.Errorf("consensus fault check: failed to inspect chain for b2: %s", err)
+			return nil, gasUsed
+		}
+		if !foundB2 {
+			log.Infof("consensus fault check: neither block in chain since %d, no fault", earliest)
+			return nil, gasUsed
+		}
+	}
+
+	return fault, gasUsed
+}
+
+// detectFault evaluates the three fault conditions against already-decoded headers, without
+// performing any signature or chain-history checks.
+func detectFault(b1, b2, b3 block.Block) *runtime.ConsensusFault {
+	if b1.Miner != b2.Miner {
+		return nil
+	}
+	if b1.Height > b2.Height {
+		return nil
+	}
+
+	// Double-fork mining fault: two blocks at the same epoch.
+	// It is not necessary to present a common ancestor of the blocks.
+	if b1.Height == b2.Height {
+		return &runtime.ConsensusFault{
+			Target: b1.Miner,
+			Epoch:  b2.Height,
+			Type:   runtime.ConsensusFaultDoubleForkMining,
+		}
+	}
+	// Time-offset mining fault: two blocks with the same parent but different epochs.
+	// The blocks have a common ancestor by definition (the parent).
+	if b1.Parents.Equals(b2.Parents) && b1.Height != b2.Height {
+		return &runtime.ConsensusFault{
+			Target: b1.Miner,
+			Epoch:  b2.Height,
+			Type:   runtime.ConsensusFaultTimeOffsetMining,
+		}
+	}
+	// Parent-grinding fault: one block's parent is a tipset that provably should have included some block but does not.
+	// B3 must prove that the higher block (B2) has grandparent equal to B1's parent.
+	if b1.Height+1 == b2.Height && !b2.Parents.Has(b1.Cid()) && b2.Parents.Has(b3.Cid()) && b3.Parents.Equals(b1.Parents) {
+		return &runtime.ConsensusFault{
+			Target: b1.Miner,
+			Epoch:  b2.Height,
+			Type:   runtime.ConsensusFaultParentGrinding,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package slasher
+
+import (
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+)
+
+// candidateFault is a locally detected, not-yet-confirmed consensus fault: two or three blocks that
+// look like a fault by the indexed history alone. handleCandidate confirms it against
+// slashing.ConsensusFaultChecker before anything is reported. b1 is always the lower (or equal)
+// height header, matching VerifyConsensusFault's h1/h2 ordering requirement.
+type candidateFault struct {
+	typ        runtime.ConsensusFaultType
+	target     address.Address
+	epoch      abi.ChainEpoch
+	b1, b2, b3 *block.Block // b3 is only set for parent-grinding
+}
+
+type epochKey struct {
+	miner address.Address
+	epoch abi.ChainEpoch
+}
+
+type parentsKey struct {
+	miner   address.Address
+	parents block.TipSetKey
+}
+
+// blockIndex keeps the recently seen block headers that a live slasher needs to spot a fault as soon
+// as a second (or third) offending block arrives, keyed the ways the three fault types need to look a
+// new block up: by (miner, epoch) for double-fork mining, by (miner, parents) for time-offset mining,
+// and by parents alone for a parent-grinding witness, whose miner need not match the grinding target's.
+type blockIndex struct {
+	mu           sync.Mutex
+	byEpoch      *lru.Cache // epochKey -> []*block.Block
+	byParents    *lru.Cache // parentsKey -> []*block.Block
+	byParentsAny *lru.Cache // block.TipSetKey -> []*block.Block
+}
+
+// add indexes blk and returns any candidate faults it reveals against blocks already indexed.
+func (idx *blockIndex) add(blk *block.Block) []candidateFault {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	var candidates []candidateFault
+
+	ek := epochKey{miner: blk.Miner, epoch: blk.Height}
+	for _, seen := range idx.blocksAt(ek) {
+		if seen.Cid() == blk.Cid() {
+			continue
+		}
+		// Double-fork mining: two distinct blocks by the same miner at the same epoch.
+		candidates = append(candidates, candidateFault{
+			typ:    runtime.ConsensusFaultDoubleForkMining,
+			target: blk.Miner,
+			epoch:  blk.Height,
+			b1:     seen,
+			b2:     blk,
+		})
+	}
+	idx.appendAt(idx.byEpoch, ek, blk)
+
+	pk := parentsKey{miner: blk.Miner, parents: blk.Parents}
+	for _, seen := range idx.blocksWithParents(pk) {
+		if seen.Height == blk.Height {
+			continue // already handled as a double-fork candidate above
+		}
+		// Time-offset mining: same miner, same parents, different epoch. Order by height so b1 is
+		// never higher than b2, as VerifyConsensusFault requires.
+		lo, hi := seen, blk
+		if lo.Height > hi.Height {
+			lo, hi = hi, lo
+		}
+		candidates = append(candidates, candidateFault{
+			typ:    runtime.ConsensusFaultTimeOffsetMining,
+			target: blk.Miner,
+			epoch:  hi.Height,
+			b1:     lo,
+			b2:     hi,
+		})
+	}
+	idx.appendAt(idx.byParents, pk, blk)
+	idx.appendAt(idx.byParentsAny, blk.Parents, blk)
+
+	candidates = append(candidates, idx.detectParentGrinding(blk)...)
+
+	return candidates
+}
+
+// detectParentGrinding looks for a parent-grinding fault using only blocks this process has already
+// observed: a block b1 by the same miner one epoch below blk whose CID blk's parents omit, together
+// with an observed witness b3 that shares b1's parents. b3's miner is deliberately unconstrained: the
+// honestly-mined sibling blk's miner excluded is, in the common case, produced by a different miner
+// than the grinding target. This only catches the fault when all three headers were seen via gossip;
+// it is not a substitute for an archival scan.
+func (idx *blockIndex) detectParentGrinding(blk *block.Block) []candidateFault {
+	var candidates []candidateFault
+
+	priorEpoch := epochKey{miner: blk.Miner, epoch: blk.Height - 1}
+	for _, b1 := range idx.blocksAt(priorEpoch) {
+		if blk.Parents.Has(b1.Cid()) {
+			continue // b1 is already blk's acknowledged parent; no grinding here
+		}
+		for _, b3 := range idx.blocksWithAnyMinerParents(b1.Parents) {
+			if !blk.Parents.Has(b3.Cid()) {
+				continue
+			}
+			candidates = append(candidates, candidateFault{
+				typ:    runtime.ConsensusFaultParentGrinding,
+				target: blk.Miner,
+				epoch:  blk.Height,
+				b1:     b1,
+				b2:     blk,
+				b3:     b3,
+			})
+		}
+	}
+
+	return candidates
+}
+
+func (idx *blockIndex) blocksAt(k epochKey) []*block.Block {
+	v, ok := idx.byEpoch.Get(k)
+	if !ok {
+		return nil
+	}
+	return v.([]*block.Block)
+}
+
+func (idx *blockIndex) blocksWithParents(k parentsKey) []*block.Block {
+	v, ok := idx.byParents.Get(k)
+	if !ok {
+		return nil
+	}
+	return v.([]*block.Block)
+}
+
+func (idx *blockIndex) blocksWithAnyMinerParents(parents block.TipSetKey) []*block.Block {
+	v, ok := idx.byParentsAny.Get(parents)
+	if !ok {
+		return nil
+	}
+	return v.([]*block.Block)
+}
+
+func (idx *blockIndex) appendAt(cache *lru.Cache, key interface{}, blk *block.Block) {
+	existing, _ := cache.Get(key)
+	var blocks []*block.Block
+	if existing != nil {
+		blocks = existing.([]*block.Block)
+	}
+	cache.Add(key, append(blocks, blk))
+}
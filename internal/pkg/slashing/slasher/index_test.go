@@ -0,0 +1,123 @@
+package slasher
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+)
+
+func mustAddress(t *testing.T, id uint64) address.Address {
+	t.Helper()
+	a, err := address.NewIDAddress(id)
+	if err != nil {
+		t.Fatalf("failed to construct test address: %s", err)
+	}
+	return a
+}
+
+func cidFromSeed(t *testing.T, seed byte) cid.Cid {
+	t.Helper()
+	sum, err := mh.Sum([]byte{seed}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("failed to hash test seed: %s", err)
+	}
+	return cid.NewCidV1(cid.Raw, sum)
+}
+
+func tipSetKeyFromSeeds(t *testing.T, seeds ...byte) block.TipSetKey {
+	t.Helper()
+	cids := make([]cid.Cid, len(seeds))
+	for i, s := range seeds {
+		cids[i] = cidFromSeed(t, s)
+	}
+	return block.NewTipSetKey(cids...)
+}
+
+func newIndex(t *testing.T) *blockIndex {
+	t.Helper()
+	idx, err := newBlockIndex(defaultIndexSize)
+	if err != nil {
+		t.Fatalf("failed to construct block index: %s", err)
+	}
+	return idx
+}
+
+func hasType(candidates []candidateFault, typ runtime.ConsensusFaultType) bool {
+	for _, c := range candidates {
+		if c.typ == typ {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBlockIndexDoubleForkMining(t *testing.T) {
+	idx := newIndex(t)
+	miner := mustAddress(t, 100)
+
+	first := &block.Block{Miner: miner, Height: 10, Parents: tipSetKeyFromSeeds(t, 1)}
+	second := &block.Block{Miner: miner, Height: 10, Parents: tipSetKeyFromSeeds(t, 2)}
+
+	if c := idx.add(first); len(c) != 0 {
+		t.Fatalf("expected no candidates for the first block seen, got %+v", c)
+	}
+	candidates := idx.add(second)
+	if !hasType(candidates, runtime.ConsensusFaultDoubleForkMining) {
+		t.Fatalf("expected a double-fork-mining candidate, got %+v", candidates)
+	}
+}
+
+func TestBlockIndexTimeOffsetMining(t *testing.T) {
+	idx := newIndex(t)
+	miner := mustAddress(t, 100)
+	parents := tipSetKeyFromSeeds(t, 1)
+
+	first := &block.Block{Miner: miner, Height: 10, Parents: parents}
+	second := &block.Block{Miner: miner, Height: 11, Parents: parents}
+
+	idx.add(first)
+	candidates := idx.add(second)
+	if !hasType(candidates, runtime.ConsensusFaultTimeOffsetMining) {
+		t.Fatalf("expected a time-offset-mining candidate, got %+v", candidates)
+	}
+}
+
+// TestBlockIndexParentGrindingCrossMinerWitness is a regression test: the witness block that proves a
+// parent-grinding fault is commonly mined by someone other than the grinding target, and the index
+// must still surface it.
+func TestBlockIndexParentGrindingCrossMinerWitness(t *testing.T) {
+	idx := newIndex(t)
+	target := mustAddress(t, 100)
+	other := mustAddress(t, 200)
+
+	grandparents := tipSetKeyFromSeeds(t, 1)
+	b1 := &block.Block{Miner: target, Height: 9, Parents: grandparents}
+	// b3 is mined by a different miner than the grinding target, but shares b1's parents.
+	b3 := &block.Block{Miner: other, Height: 9, Parents: grandparents}
+	// blk acknowledges b3 as its parent, omitting b1, at the next epoch.
+	blk := &block.Block{Miner: target, Height: 10, Parents: block.NewTipSetKey(b3.Cid())}
+
+	idx.add(b1)
+	idx.add(b3)
+	candidates := idx.add(blk)
+
+	if !hasType(candidates, runtime.ConsensusFaultParentGrinding) {
+		t.Fatalf("expected a parent-grinding candidate with a cross-miner witness, got %+v", candidates)
+	}
+}
+
+func TestBlockIndexNoCandidatesForUnrelatedBlocks(t *testing.T) {
+	idx := newIndex(t)
+	a := &block.Block{Miner: mustAddress(t, 100), Height: 10, Parents: tipSetKeyFromSeeds(t, 1)}
+	b := &block.Block{Miner: mustAddress(t, 200), Height: 20, Parents: tipSetKeyFromSeeds(t, 2)}
+
+	idx.add(a)
+	if c := idx.add(b); len(c) != 0 {
+		t.Fatalf("expected no candidates for unrelated blocks, got %+v", c)
+	}
+}
@@ -0,0 +1,231 @@
+// Package slasher turns the passive slashing.ConsensusFaultChecker into a productive service: it
+// watches incoming blocks, detects the three consensus fault types against what it has recently seen,
+// confirms each candidate with the checker, and reports confirmed faults on chain.
+package slasher
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	lru "github.com/hashicorp/golang-lru"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing"
+)
+
+var log = logging.Logger("slasher")
+
+// defaultIndexSize bounds the per-miner block indices kept in memory. It is sized generously for the
+// handful of blocks a miner can legitimately produce within the dispute window, not for adversarial
+// flooding; BlockSource implementations are expected to rate-limit per-miner ingestion upstream.
+const defaultIndexSize = 8192
+
+// BlockSource supplies the stream of newly-seen block headers for the slasher to index, typically a
+// thin wrapper around the node's block-propagation subscription.
+type BlockSource interface {
+	SubscribeBlocks(ctx context.Context) (<-chan *block.Block, error)
+}
+
+// ChainHeadView supplies the state needed to verify and report a fault as of the current chain head.
+type ChainHeadView interface {
+	Head() block.TipSetKey
+	HeadEpoch() abi.ChainEpoch
+	StateView(head block.TipSetKey) (slashing.FaultStateView, error)
+}
+
+// MessageSender submits a confirmed fault on chain, decoupling the slasher from the concrete
+// message-pool and actor-method wiring used to build a ReportConsensusFault message.
+type MessageSender interface {
+	SendReportConsensusFault(ctx context.Context, reporter address.Address, target address.Address, h1, h2, extra []byte) error
+}
+
+// Config controls the slasher's behaviour.
+type Config struct {
+	// Reporter is the address ReportConsensusFault messages are sent from.
+	Reporter address.Address
+	// EarliestEpochWindow bounds how far back a candidate's blocks must still be provable in the chain;
+	// VerifyConsensusFault is called with earliest = head epoch - EarliestEpochWindow.
+	EarliestEpochWindow abi.ChainEpoch
+}
+
+// Metrics is a point-in-time snapshot of the slasher's counters.
+type Metrics struct {
+	Detected int64
+	Reported int64
+	Rejected int64
+}
+
+// Slasher watches the block stream, detects candidate consensus faults, confirms them against
+// checker, and reports confirmed faults via sender.
+type Slasher struct {
+	cfg     Config
+	source  BlockSource
+	view    ChainHeadView
+	checker *slashing.ConsensusFaultChecker
+	sender  MessageSender
+
+	index *blockIndex
+
+	mu       sync.Mutex
+	reported map[reportKey]struct{} // dedup of faults already reported
+
+	detected int64
+	sent     int64
+	rejected int64
+}
+
+type reportKey struct {
+	target address.Address
+	epoch  abi.ChainEpoch
+	typ    runtime.ConsensusFaultType
+}
+
+// New constructs a Slasher. checker is typically slashing.NewFaultChecker bound to the node's chain
+// reader.
+func New(cfg Config, source BlockSource, view ChainHeadView, checker *slashing.ConsensusFaultChecker, sender MessageSender) (*Slasher, error) {
+	index, err := newBlockIndex(defaultIndexSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct slasher block index")
+	}
+	return &Slasher{
+		cfg:      cfg,
+		source:   source,
+		view:     view,
+		checker:  checker,
+		sender:   sender,
+		index:    index,
+		reported: make(map[reportKey]struct{}),
+	}, nil
+}
+
+// Run subscribes to the block stream and processes blocks until ctx is cancelled.
+func (s *Slasher) Run(ctx context.Context) error {
+	blocks, err := s.source.SubscribeBlocks(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to blocks")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case blk, ok := <-blocks:
+			if !ok {
+				return nil
+			}
+			s.observe(ctx, blk)
+		}
+	}
+}
+
+// observe indexes a newly seen block and checks it against the index for each fault type.
+func (s *Slasher) observe(ctx context.Context, blk *block.Block) {
+	candidates := s.index.add(blk)
+	for _, c := range candidates {
+		s.handleCandidate(ctx, c)
+	}
+}
+
+// handleCandidate confirms a locally detected candidate against the checker and, if confirmed,
+// reports it.
+func (s *Slasher) handleCandidate(ctx context.Context, c candidateFault) {
+	atomic.AddInt64(&s.detected, 1)
+
+	if s.alreadyReported(c.target, c.epoch, c.typ) {
+		return
+	}
+
+	h1, err := encoding.Encode(c.b1)
+	if err != nil {
+		log.Errorf("slasher: failed to encode h1 for candidate %v fault by %s: %s", c.typ, c.target, err)
+		atomic.AddInt64(&s.rejected, 1)
+		return
+	}
+	h2, err := encoding.Encode(c.b2)
+	if err != nil {
+		log.Errorf("slasher: failed to encode h2 for candidate %v fault by %s: %s", c.typ, c.target, err)
+		atomic.AddInt64(&s.rejected, 1)
+		return
+	}
+	var extra []byte
+	if c.b3 != nil {
+		extra, err = encoding.Encode(c.b3)
+		if err != nil {
+			log.Errorf("slasher: failed to encode extra for candidate %v fault by %s: %s", c.typ, c.target, err)
+			atomic.AddInt64(&s.rejected, 1)
+			return
+		}
+	}
+
+	head := s.view.Head()
+	view, err := s.view.StateView(head)
+	if err != nil {
+		log.Errorf("slasher: failed to load state view at head %s: %s", head, err)
+		atomic.AddInt64(&s.rejected, 1)
+		return
+	}
+	earliest := s.view.HeadEpoch() - s.cfg.EarliestEpochWindow
+
+	fault, err := s.checker.VerifyConsensusFault(ctx, h1, h2, extra, head, view, earliest)
+	if err != nil {
+		log.Infof("slasher: candidate %v fault by %s at epoch %d rejected: %s", c.typ, c.target, c.epoch, err)
+		atomic.AddInt64(&s.rejected, 1)
+		return
+	}
+
+	if err := s.sender.SendReportConsensusFault(ctx, s.cfg.Reporter, fault.Target, h1, h2, extra); err != nil {
+		log.Errorf("slasher: failed to report confirmed %v fault by %s: %s", fault.Type, fault.Target, err)
+		return
+	}
+
+	s.markReported(fault.Target, fault.Epoch, fault.Type)
+	atomic.AddInt64(&s.sent, 1)
+	log.Infof("slasher: reported %v fault by %s at epoch %d", fault.Type, fault.Target, fault.Epoch)
+}
+
+func (s *Slasher) alreadyReported(target address.Address, epoch abi.ChainEpoch, typ runtime.ConsensusFaultType) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.reported[reportKey{target: target, epoch: epoch, typ: typ}]
+	return ok
+}
+
+func (s *Slasher) markReported(target address.Address, epoch abi.ChainEpoch, typ runtime.ConsensusFaultType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reported[reportKey{target: target, epoch: epoch, typ: typ}] = struct{}{}
+}
+
+// Metrics returns a snapshot of the slasher's detected/reported/rejected counters.
+func (s *Slasher) Metrics() Metrics {
+	return Metrics{
+		Detected: atomic.LoadInt64(&s.detected),
+		Reported: atomic.LoadInt64(&s.sent),
+		Rejected: atomic.LoadInt64(&s.rejected),
+	}
+}
+
+// newBlockIndex is split out so New can report a construction error instead of panicking; golang-lru
+// only errors on a non-positive size, which defaultIndexSize never triggers.
+func newBlockIndex(size int) (*blockIndex, error) {
+	byEpoch, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	byParents, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	byParentsAny, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &blockIndex{byEpoch: byEpoch, byParents: byParents, byParentsAny: byParentsAny}, nil
+}
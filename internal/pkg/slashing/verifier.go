@@ -0,0 +1,25 @@
+package slashing
+
+import (
+	"context"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+)
+
+// Verifier abstracts the single expensive check performed while verifying a consensus fault report:
+// validating a block's signature. A higher layer can inject an FFI-accelerated or otherwise optimized
+// implementation without the fault-checking logic in this package having to know about it. Batching or
+// aggregating verification work across reports, where it happens, is the caller's concern (see
+// VerifyConsensusFaults' sigCache) rather than something Verifier itself provides.
+type Verifier interface {
+	// VerifySignature checks that blk carries a valid signature by its worker, in the context of view.
+	VerifySignature(ctx context.Context, view FaultStateView, blk block.Block) error
+}
+
+// defaultVerifier is the Verifier backing NewFaultChecker: it validates signatures with the existing
+// state.NewSignatureValidator.
+type defaultVerifier struct{}
+
+func (defaultVerifier) VerifySignature(ctx context.Context, view FaultStateView, blk block.Block) error {
+	return verifyBlockSignature(ctx, view, blk)
+}
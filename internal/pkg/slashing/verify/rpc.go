@@ -0,0 +1,23 @@
+package verify
+
+import "context"
+
+// API is the method set the node's JSON-RPC server dispatches to for slashing-related calls, following
+// the node's convention of a plain exported method per RPC endpoint. "Slashing.VerifyConsensusFault" is
+// the resulting JSON-RPC method name.
+type API struct {
+	service *Service
+}
+
+// NewAPI wraps service for JSON-RPC dispatch.
+func NewAPI(service *Service) *API {
+	return &API{service: service}
+}
+
+// VerifyConsensusFault is the JSON-RPC counterpart to slashingVerifyCmd: it runs the same check. A
+// rejected-but-well-formed report comes back as a Result with Rejected set and a nil error; a
+// transient failure to complete the check (e.g. a chain lookup error) is surfaced as a real,
+// retryable error instead.
+func (a *API) VerifyConsensusFault(ctx context.Context, req Request) (Result, error) {
+	return a.service.Verify(ctx, req)
+}
@@ -0,0 +1,34 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing"
+)
+
+func TestAPIVerifyConsensusFaultPropagatesChainLookupError(t *testing.T) {
+	h1, h2 := doubleForkHeaders(t)
+	checker := slashing.NewFaultCheckerWithVerifier(failingChainReader{}, passingVerifier{})
+	api := NewAPI(NewService(checker, nil))
+
+	_, err := api.VerifyConsensusFault(context.Background(), Request{H1: h1, H2: h2, Mode: ModeStrict})
+	if !errors.Is(err, slashing.ErrChainLookup) {
+		t.Fatalf("expected errors.Is(err, slashing.ErrChainLookup), got %v", err)
+	}
+}
+
+func TestAPIVerifyConsensusFaultDryRun(t *testing.T) {
+	h1, h2 := doubleForkHeaders(t)
+	checker := slashing.NewFaultCheckerWithVerifier(nil, passingVerifier{})
+	api := NewAPI(NewService(checker, nil))
+
+	result, err := api.VerifyConsensusFault(context.Background(), Request{H1: h1, H2: h2, Mode: ModeDryRun})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.FaultType == "" {
+		t.Fatal("expected FaultType to be set on a confirmed fault")
+	}
+}
@@ -0,0 +1,107 @@
+// Package verify exposes slashing.ConsensusFaultChecker.VerifyConsensusFault as a transport-agnostic
+// service, so it can be wired into both the node's command layer and its JSON-RPC API without either
+// one knowing about the checker directly.
+package verify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing"
+)
+
+// Mode selects how a Request is checked.
+type Mode string
+
+const (
+	// ModeStrict matches on-chain semantics: a fault is only confirmed if h1 or h2 is provably in the
+	// chain defined by Head since Earliest. This is the default.
+	ModeStrict Mode = "strict"
+	// ModeDryRun skips the chain-in-history check, for offline analysis of two headers pulled from a
+	// historical archive the caller has no live chain access to.
+	ModeDryRun Mode = "dry-run"
+)
+
+// Request is a consensus fault report submitted for verification, with headers already decoded from
+// their wire encoding (see DecodeHeader).
+type Request struct {
+	H1, H2, Extra []byte
+	Head          block.TipSetKey // ignored in ModeDryRun
+	Earliest      abi.ChainEpoch  // ignored in ModeDryRun
+	Mode          Mode
+}
+
+// Result is the outcome of verifying a Request: either a confirmed fault, or the reason it was
+// rejected.
+type Result struct {
+	FaultType string          `json:"fault_type,omitempty"`
+	Target    address.Address `json:"target,omitempty"`
+	Epoch     abi.ChainEpoch  `json:"epoch,omitempty"`
+	Rejected  string          `json:"rejected,omitempty"`
+}
+
+// Service verifies consensus fault reports against a fixed state view, independent of any particular
+// chain head the caller happens to be looking at.
+type Service struct {
+	checker *slashing.ConsensusFaultChecker
+	view    slashing.FaultStateView
+}
+
+// NewService constructs a Service backed by checker, resolving state through view.
+func NewService(checker *slashing.ConsensusFaultChecker, view slashing.FaultStateView) *Service {
+	return &Service{checker: checker, view: view}
+}
+
+// Verify checks req and returns a structured result. A rejected report is not an error: Result.Rejected
+// carries the reason, so a watchtower can log or retry on its own terms. A non-nil error means the
+// check itself could not be completed — today that's always slashing.ErrChainLookup, a transient
+// infrastructure failure distinct from a verdict, and callers should retry rather than treat it as a
+// rejection.
+func (s *Service) Verify(ctx context.Context, req Request) (Result, error) {
+	if req.Mode == ModeDryRun {
+		fault, err := s.checker.VerifyConsensusFaultOffline(ctx, req.H1, req.H2, req.Extra, s.view)
+		return toResult(fault, err)
+	}
+	fault, err := s.checker.VerifyConsensusFault(ctx, req.H1, req.H2, req.Extra, req.Head, s.view, req.Earliest)
+	return toResult(fault, err)
+}
+
+func toResult(fault *runtime.ConsensusFault, err error) (Result, error) {
+	if err != nil {
+		if errors.Is(err, slashing.ErrChainLookup) {
+			return Result{}, err
+		}
+		return Result{Rejected: err.Error()}, nil
+	}
+	return Result{
+		FaultType: fmt.Sprintf("%v", fault.Type),
+		Target:    fault.Target,
+		Epoch:     fault.Epoch,
+	}, nil
+}
+
+// DecodeHeader decodes a block header submitted as hex (optionally 0x-prefixed) or standard/URL-safe
+// base64, the encodings external tools (e.g. watchtowers) are most likely to hand in over the CLI or
+// JSON-RPC.
+func DecodeHeader(s string) ([]byte, error) {
+	trimmed := strings.TrimPrefix(s, "0x")
+	if b, err := hex.DecodeString(trimmed); err == nil {
+		return b, nil
+	}
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	if b, err := base64.URLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return nil, fmt.Errorf("header %q is neither valid hex nor base64", s)
+}
@@ -0,0 +1,151 @@
+package verify
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/slashing"
+)
+
+func mustAddress(t *testing.T, id uint64) address.Address {
+	t.Helper()
+	a, err := address.NewIDAddress(id)
+	if err != nil {
+		t.Fatalf("failed to construct test address: %s", err)
+	}
+	return a
+}
+
+func tipSetKeyFromSeeds(t *testing.T, seeds ...byte) block.TipSetKey {
+	t.Helper()
+	cids := make([]cid.Cid, len(seeds))
+	for i, s := range seeds {
+		sum, err := mh.Sum([]byte{s}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatalf("failed to hash test seed: %s", err)
+		}
+		cids[i] = cid.NewCidV1(cid.Raw, sum)
+	}
+	return block.NewTipSetKey(cids...)
+}
+
+// passingVerifier always accepts a block's signature, so tests can exercise Service.Verify without
+// depending on the real signature-checking machinery.
+type passingVerifier struct{}
+
+func (passingVerifier) VerifySignature(ctx context.Context, view slashing.FaultStateView, blk block.Block) error {
+	return nil
+}
+
+// failingChainReader simulates a chain lookup that cannot be completed, independent of whether the
+// report itself is well-formed.
+type failingChainReader struct{}
+
+func (failingChainReader) GetTipSet(block.TipSetKey) (block.TipSet, error) {
+	return block.TipSet{}, errors.New("datastore unavailable")
+}
+
+func doubleForkHeaders(t *testing.T) (h1, h2 []byte) {
+	t.Helper()
+	miner := mustAddress(t, 100)
+	parents := tipSetKeyFromSeeds(t, 1)
+	b1 := block.Block{Miner: miner, Height: 10, Parents: parents}
+	b2 := block.Block{Miner: miner, Height: 10, Parents: tipSetKeyFromSeeds(t, 2)}
+
+	h1, err := encoding.Encode(b1)
+	if err != nil {
+		t.Fatalf("failed to encode b1: %s", err)
+	}
+	h2, err = encoding.Encode(b2)
+	if err != nil {
+		t.Fatalf("failed to encode b2: %s", err)
+	}
+	return h1, h2
+}
+
+func TestDecodeHeader(t *testing.T) {
+	raw := []byte("a block header")
+
+	cases := []struct {
+		name string
+		in   string
+	}{
+		{name: "hex", in: hex.EncodeToString(raw)},
+		{name: "0x-prefixed hex", in: "0x" + hex.EncodeToString(raw)},
+		{name: "standard base64", in: base64.StdEncoding.EncodeToString(raw)},
+		{name: "url-safe base64", in: base64.URLEncoding.EncodeToString(raw)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeHeader(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != string(raw) {
+				t.Fatalf("expected %q, got %q", raw, got)
+			}
+		})
+	}
+
+	if _, err := DecodeHeader("not valid hex or base64 !!"); err == nil {
+		t.Fatal("expected an error for an undecodable header")
+	}
+}
+
+func TestServiceVerifyDryRunDetectsFault(t *testing.T) {
+	h1, h2 := doubleForkHeaders(t)
+	checker := slashing.NewFaultCheckerWithVerifier(nil, passingVerifier{})
+	service := NewService(checker, nil)
+
+	result, err := service.Verify(context.Background(), Request{H1: h1, H2: h2, Mode: ModeDryRun})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Rejected != "" {
+		t.Fatalf("expected a confirmed fault, got rejected: %q", result.Rejected)
+	}
+	if result.FaultType == "" {
+		t.Fatal("expected FaultType to be set on a confirmed fault")
+	}
+}
+
+func TestServiceVerifyRejectionIsNotAnError(t *testing.T) {
+	h1, _ := doubleForkHeaders(t)
+	checker := slashing.NewFaultCheckerWithVerifier(nil, passingVerifier{})
+	service := NewService(checker, nil)
+
+	// Identical headers: a well-formed rejection, not a Go error.
+	result, err := service.Verify(context.Background(), Request{H1: h1, H2: h1, Mode: ModeDryRun})
+	if err != nil {
+		t.Fatalf("expected a rejection, not an error: %s", err)
+	}
+	if result.Rejected == "" {
+		t.Fatal("expected Result.Rejected to explain why the report was rejected")
+	}
+}
+
+func TestServiceVerifyStrictSurfacesChainLookupAsError(t *testing.T) {
+	h1, h2 := doubleForkHeaders(t)
+	checker := slashing.NewFaultCheckerWithVerifier(failingChainReader{}, passingVerifier{})
+	service := NewService(checker, nil)
+
+	result, err := service.Verify(context.Background(), Request{H1: h1, H2: h2, Mode: ModeStrict})
+	if err == nil {
+		t.Fatal("expected a chain-lookup failure to come back as a real error")
+	}
+	if !errors.Is(err, slashing.ErrChainLookup) {
+		t.Fatalf("expected errors.Is(err, slashing.ErrChainLookup), got %v", err)
+	}
+	if result.Rejected != "" {
+		t.Fatalf("a transient chain-lookup failure must not be folded into Result.Rejected, got %q", result.Rejected)
+	}
+}